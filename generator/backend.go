@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"github.com/cv21/gen/pkg"
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+)
+
+const (
+	// BackendTestify generates stretchr/testify/mock-compatible mocks. This is the default.
+	BackendTestify = "testify"
+
+	// BackendGomock generates golang/mock/gomock-compatible mocks.
+	BackendGomock = "gomock"
+)
+
+// backend knows how to render a full mock declaration (struct, constructor and
+// methods) for a single interface in a particular mocking library's style. It
+// may also return extra files to emit alongside the mock itself, e.g. shared
+// helper scaffolding that must only be written once per target package; most
+// backends return nil.
+//
+// New backends (e.g. moq, pegomock) can be added by implementing this
+// interface and registering them in backendFor.
+type backend interface {
+	Generate(f *File, params *generatorParams, mockStructName, interfaceName string, methods []*types.Function, typeParams []TypeParam) []pkg.GenerateResultFile
+}
+
+// Returns the backend to use for the given params, defaulting to testify
+// when Backend is empty so existing configs keep working unchanged.
+func backendFor(params *generatorParams) backend {
+	switch params.Backend {
+	case BackendGomock:
+		return &gomockBackend{}
+	case BackendTestify, "":
+		return &testifyBackend{}
+	default:
+		return &testifyBackend{}
+	}
+}
+
+// It is a convenient func for calling pkg.TypeQual. Identifiers that refer to
+// one of the interface's type parameters are emitted as bare identifiers,
+// since pkg.TypeQual would otherwise treat them as local types needing an
+// import of SourcePackagePath.
+func typeQual(params *generatorParams, typeParams []TypeParam, t types.Type) *Statement {
+	if tn, ok := t.(types.TName); ok && isTypeParamName(typeParams, tn.TypeName) {
+		return Id(tn.TypeName)
+	}
+	return pkg.TypeQual(params.SourcePackagePath, params.TargetPackagePath, t)
+}
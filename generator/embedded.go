@@ -0,0 +1,304 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+
+	"github.com/vetcher/go-astra/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// resolveMethods returns the full, flattened method set declared on iface:
+// its own directly declared methods plus those promoted from embedded
+// interfaces, including interfaces embedded from other packages (e.g.
+// io.ReadCloser), which go-astra cannot see on its own. Methods are
+// deduplicated by name and interleaved in the order they appear in source -
+// so an interface that embeds another before declaring its own methods gets
+// the embedded methods first - courtesy of loadPromotedMethods, which
+// already computes that merged order; diamond embedding doesn't produce
+// duplicate entries.
+//
+// If the embedded interfaces can't be resolved (e.g. the source package
+// can't be loaded, or one of iface's own methods uses a generic type
+// parameter that convertType can't yet represent), it falls back to
+// iface.Methods - in source declaration order, but without any promoted
+// methods - and returns the error alongside it so the caller can decide
+// whether to continue.
+func resolveMethods(sourcePackagePath string, iface *types.Interface) ([]*types.Function, error) {
+	merged, err := loadPromotedMethods(sourcePackagePath, iface.Name)
+	if err != nil {
+		return append([]*types.Function(nil), iface.Methods...), fmt.Errorf("resolve embedded interfaces of %s: %w", iface.Name, err)
+	}
+	if len(merged) == 0 {
+		// loadPromotedMethods found nothing, e.g. because iface couldn't be
+		// looked up via go/types; iface.Methods is all we have.
+		return append([]*types.Function(nil), iface.Methods...), nil
+	}
+	return merged, nil
+}
+
+// loadPromotedMethods loads sourcePackagePath with go/packages, which (unlike
+// go-astra) fully resolves embedded interfaces - including diamond embedding
+// and interfaces embedded from other packages - and converts the resulting
+// method set into go-astra *types.Function values so the rest of the
+// generator can treat promoted methods exactly like directly declared ones.
+//
+// go/types.Interface.Method orders the flattened method set by unique Id
+// (effectively alphabetically), not by declaration order, so the ordering
+// itself is recovered by walking the AST of interfaceName and each interface
+// it embeds, recursively; go/types is only used to resolve a method name to
+// its signature once the order is known.
+func loadPromotedMethods(sourcePackagePath, interfaceName string) ([]*types.Function, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedDeps | packages.NeedSyntax | packages.NeedImports,
+	}
+	roots, err := packages.Load(cfg, sourcePackagePath)
+	if err != nil {
+		return nil, fmt.Errorf("load package %s: %w", sourcePackagePath, err)
+	}
+
+	byPath := make(map[string]*packages.Package)
+	packages.Visit(roots, func(p *packages.Package) bool {
+		byPath[p.PkgPath] = p
+		return true
+	}, nil)
+
+	var rootPkg *packages.Package
+	var obj gotypes.Object
+	for _, p := range roots {
+		if o := p.Types.Scope().Lookup(interfaceName); o != nil {
+			rootPkg, obj = p, o
+			break
+		}
+	}
+	if obj == nil {
+		return nil, nil
+	}
+
+	underlying, ok := obj.Type().Underlying().(*gotypes.Interface)
+	if !ok {
+		return nil, nil
+	}
+
+	byName := make(map[string]*gotypes.Func, underlying.NumMethods())
+	for i := 0; i < underlying.NumMethods(); i++ {
+		m := underlying.Method(i)
+		byName[m.Name()] = m
+	}
+
+	orderedNames, err := declOrderMethodNames(byPath, rootPkg.PkgPath, interfaceName)
+	if err != nil {
+		// Best effort: fall back to go/types' own (alphabetical) order rather
+		// than failing the whole resolution over an ordering nicety.
+		orderedNames = orderedNames[:0]
+		for i := 0; i < underlying.NumMethods(); i++ {
+			orderedNames = append(orderedNames, underlying.Method(i).Name())
+		}
+	}
+
+	methods := make([]*types.Function, 0, len(orderedNames))
+	for _, name := range orderedNames {
+		fn, ok := byName[name]
+		if !ok {
+			continue
+		}
+		converted, err := convertMethod(fn)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, converted)
+	}
+	return methods, nil
+}
+
+// declOrderMethodNames returns the method names of the interface typeName,
+// declared in pkgPath, in the order they appear in source - including names
+// contributed by embedded interfaces, recursively, deduplicated on first
+// occurrence so diamond embedding doesn't produce repeats.
+func declOrderMethodNames(byPath map[string]*packages.Package, pkgPath, typeName string) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	if err := collectDeclOrderMethodNames(byPath, pkgPath, typeName, seen, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func collectDeclOrderMethodNames(byPath map[string]*packages.Package, pkgPath, typeName string, seen map[string]bool, out *[]string) error {
+	p, ok := byPath[pkgPath]
+	if !ok {
+		return fmt.Errorf("package %s not loaded", pkgPath)
+	}
+
+	spec := findInterfaceType(p, typeName)
+	if spec == nil {
+		return fmt.Errorf("interface %s not found in %s", typeName, pkgPath)
+	}
+
+	for _, field := range spec.Methods.List {
+		if len(field.Names) > 0 {
+			for _, name := range field.Names {
+				if seen[name.Name] {
+					continue
+				}
+				seen[name.Name] = true
+				*out = append(*out, name.Name)
+			}
+			continue
+		}
+
+		embeddedPkgPath, embeddedName, ok := resolveEmbeddedTypeRef(p, field.Type)
+		if !ok {
+			continue
+		}
+		if err := collectDeclOrderMethodNames(byPath, embeddedPkgPath, embeddedName, seen, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findInterfaceType locates the *ast.InterfaceType declaring typeName in p.
+func findInterfaceType(p *packages.Package, typeName string) *ast.InterfaceType {
+	for _, file := range p.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range genDecl.Specs {
+				typeSpec, ok := s.(*ast.TypeSpec)
+				if !ok || typeSpec.Name.Name != typeName {
+					continue
+				}
+				if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+					return iface
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveEmbeddedTypeRef resolves an embedded interface field's type
+// expression (a bare Ident for a same-package type, or a Selector for
+// pkg.Type) to the package path and name it refers to.
+func resolveEmbeddedTypeRef(p *packages.Package, expr ast.Expr) (pkgPath, name string, ok bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if tn, isTypeName := p.TypesInfo.Uses[e].(*gotypes.TypeName); isTypeName && tn.Pkg() != nil {
+			return tn.Pkg().Path(), tn.Name(), true
+		}
+		return p.PkgPath, e.Name, true
+	case *ast.SelectorExpr:
+		if tn, isTypeName := p.TypesInfo.Uses[e.Sel].(*gotypes.TypeName); isTypeName && tn.Pkg() != nil {
+			return tn.Pkg().Path(), tn.Name(), true
+		}
+	}
+	return "", "", false
+}
+
+// convertMethod converts a single resolved go/types method into the
+// go-astra *types.Function shape the rest of the generator expects.
+func convertMethod(m *gotypes.Func) (*types.Function, error) {
+	sig := m.Type().(*gotypes.Signature)
+
+	args, err := convertTuple(sig.Params(), sig.Variadic(), "arg")
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", m.Name(), err)
+	}
+
+	results, err := convertTuple(sig.Results(), false, "ret")
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", m.Name(), err)
+	}
+
+	return &types.Function{
+		Base: types.Base{
+			Name: m.Name(),
+		},
+		Args:    args,
+		Results: results,
+	}, nil
+}
+
+func convertTuple(tuple *gotypes.Tuple, variadic bool, namePrefix string) ([]types.Variable, error) {
+	vars := make([]types.Variable, 0, tuple.Len())
+	for i := 0; i < tuple.Len(); i++ {
+		v := tuple.At(i)
+
+		name := v.Name()
+		if name == "" {
+			name = fmt.Sprintf("%s%d", namePrefix, i)
+		}
+
+		isLastVariadic := variadic && i == tuple.Len()-1
+
+		t, err := convertType(v.Type(), isLastVariadic)
+		if err != nil {
+			return nil, err
+		}
+
+		vars = append(vars, types.Variable{Base: types.Base{Name: name}, Type: t})
+	}
+	return vars, nil
+}
+
+// convertType converts a go/types.Type into the equivalent go-astra
+// types.Type. asVariadic wraps the final parameter of a variadic signature
+// in TEllipsis instead of TArray, matching how go-astra represents `...T`.
+func convertType(t gotypes.Type, asVariadic bool) (types.Type, error) {
+	switch t := t.(type) {
+	case *gotypes.Basic:
+		return types.TName{TypeName: t.Name()}, nil
+	case *gotypes.Pointer:
+		next, err := convertType(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return types.TPointer{Next: next}, nil
+	case *gotypes.Slice:
+		next, err := convertType(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		if asVariadic {
+			return types.TEllipsis{Next: next}, nil
+		}
+		return types.TArray{IsSlice: true, Next: next}, nil
+	case *gotypes.Array:
+		next, err := convertType(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return types.TArray{ArrayLen: int(t.Len()), Next: next}, nil
+	case *gotypes.Map:
+		key, err := convertType(t.Key(), false)
+		if err != nil {
+			return nil, err
+		}
+		value, err := convertType(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return types.TMap{Key: key, Value: value}, nil
+	case *gotypes.Named:
+		pkg := t.Obj().Pkg()
+		if pkg == nil {
+			// Universe-scope named types, e.g. the built-in "error".
+			return types.TName{TypeName: t.Obj().Name()}, nil
+		}
+		return types.TImport{
+			Import: &types.Import{Base: types.Base{Name: pkg.Name()}, Package: pkg.Path()},
+			Next:   types.TName{TypeName: t.Obj().Name()},
+		}, nil
+	case *gotypes.Interface:
+		if t.NumMethods() == 0 {
+			return types.TName{TypeName: "interface{}"}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %s while resolving a promoted embedded method", t.String())
+}
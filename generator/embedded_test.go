@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/vetcher/go-astra/types"
+)
+
+const embedFixturePackage = "github.com/cv21/gen-generator-mock/generator/testdata/embediface"
+
+// TestResolveMethodsPreservesEmbedPosition confirms Store's embedded
+// io.Closer is interleaved in its actual source position (before Get/Put),
+// not appended after every directly declared method.
+func TestResolveMethodsPreservesEmbedPosition(t *testing.T) {
+	iface := &types.Interface{
+		Base: types.Base{Name: "Store"},
+		Methods: []*types.Function{
+			{Base: types.Base{Name: "Get"}},
+			{Base: types.Base{Name: "Put"}},
+		},
+	}
+
+	methods, err := resolveMethods(embedFixturePackage, iface)
+	if err != nil {
+		t.Fatalf("resolveMethods: %v", err)
+	}
+
+	var names []string
+	for _, m := range methods {
+		names = append(names, m.Name)
+	}
+
+	want := []string{"Close", "Get", "Put"}
+	if len(names) != len(want) {
+		t.Fatalf("method order = %v, want %v", names, want)
+	}
+	for i, name := range want {
+		if names[i] != name {
+			t.Errorf("method order = %v, want %v", names, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"fmt"
+	gotypes "go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeParam describes a single type parameter declared on a generic
+// interface, e.g. the `T any` in `type Repository[T any] interface { ... }`.
+type TypeParam struct {
+	Name       string
+	Constraint string
+}
+
+// resolveTypeParams loads the source package with go/packages and returns the
+// type parameters declared on interfaceName, or nil if it isn't generic.
+//
+// go-astra (used for the rest of the parsing) doesn't surface Go 1.18+ type
+// parameters, so this is a narrow, best-effort second pass over go/types that
+// only runs when we need generics information.
+func resolveTypeParams(sourcePackagePath, interfaceName string) ([]TypeParam, error) {
+	cfg := &packages.Config{Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps}
+	pkgs, err := packages.Load(cfg, sourcePackagePath)
+	if err != nil {
+		return nil, fmt.Errorf("load package %s: %w", sourcePackagePath, err)
+	}
+
+	for _, p := range pkgs {
+		obj := p.Types.Scope().Lookup(interfaceName)
+		if obj == nil {
+			continue
+		}
+
+		named, ok := obj.Type().(*gotypes.Named)
+		if !ok {
+			continue
+		}
+
+		tparams := named.TypeParams()
+		if tparams == nil {
+			return nil, nil
+		}
+
+		result := make([]TypeParam, 0, tparams.Len())
+		for i := 0; i < tparams.Len(); i++ {
+			tp := tparams.At(i)
+			result = append(result, TypeParam{
+				Name:       tp.Obj().Name(),
+				Constraint: tp.Constraint().String(),
+			})
+		}
+		return result, nil
+	}
+
+	return nil, nil
+}
+
+// typeParamsDeclClause renders the `[T any, K comparable]` clause used on a
+// generic type declaration. Returns "" when typeParams is empty.
+func typeParamsDeclClause(typeParams []TypeParam) string {
+	if len(typeParams) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(typeParams))
+	for i, tp := range typeParams {
+		parts[i] = tp.Name + " " + tp.Constraint
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// typeParamsUseClause renders the `[T, K]` clause used to reference an
+// already-declared generic type, e.g. on a method receiver. Returns "" when
+// typeParams is empty.
+func typeParamsUseClause(typeParams []TypeParam) string {
+	if len(typeParams) == 0 {
+		return ""
+	}
+
+	names := make([]string, len(typeParams))
+	for i, tp := range typeParams {
+		names[i] = tp.Name
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// isTypeParamName reports whether name refers to one of typeParams.
+func isTypeParamName(typeParams []TypeParam, name string) bool {
+	for _, tp := range typeParams {
+		if tp.Name == name {
+			return true
+		}
+	}
+	return false
+}
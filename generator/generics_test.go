@@ -0,0 +1,60 @@
+package generator
+
+import "testing"
+
+const genericFixturePackage = "github.com/cv21/gen-generator-mock/generator/testdata/genericiface"
+
+func TestResolveTypeParams(t *testing.T) {
+	typeParams, err := resolveTypeParams(genericFixturePackage, "Repository")
+	if err != nil {
+		t.Fatalf("resolveTypeParams: %v", err)
+	}
+
+	if len(typeParams) != 1 {
+		t.Fatalf("got %d type params, want 1: %+v", len(typeParams), typeParams)
+	}
+	if typeParams[0].Name != "T" {
+		t.Errorf("type param name = %q, want %q", typeParams[0].Name, "T")
+	}
+	if typeParams[0].Constraint != "any" {
+		t.Errorf("type param constraint = %q, want %q", typeParams[0].Constraint, "any")
+	}
+}
+
+func TestResolveTypeParamsNonGeneric(t *testing.T) {
+	typeParams, err := resolveTypeParams(genericFixturePackage, "Pinger")
+	if err != nil {
+		t.Fatalf("resolveTypeParams: %v", err)
+	}
+	if typeParams != nil {
+		t.Errorf("typeParams = %+v, want nil for a non-generic interface", typeParams)
+	}
+}
+
+func TestTypeParamsDeclAndUseClause(t *testing.T) {
+	typeParams := []TypeParam{{Name: "T", Constraint: "any"}}
+
+	if got, want := typeParamsDeclClause(typeParams), "[T any]"; got != want {
+		t.Errorf("typeParamsDeclClause(%+v) = %q, want %q", typeParams, got, want)
+	}
+	if got, want := typeParamsUseClause(typeParams), "[T]"; got != want {
+		t.Errorf("typeParamsUseClause(%+v) = %q, want %q", typeParams, got, want)
+	}
+	if got, want := typeParamsDeclClause(nil), ""; got != want {
+		t.Errorf("typeParamsDeclClause(nil) = %q, want %q", got, want)
+	}
+	if got, want := typeParamsUseClause(nil), ""; got != want {
+		t.Errorf("typeParamsUseClause(nil) = %q, want %q", got, want)
+	}
+}
+
+func TestIsTypeParamName(t *testing.T) {
+	typeParams := []TypeParam{{Name: "T", Constraint: "any"}}
+
+	if !isTypeParamName(typeParams, "T") {
+		t.Errorf("isTypeParamName(%+v, %q) = false, want true", typeParams, "T")
+	}
+	if isTypeParamName(typeParams, "K") {
+		t.Errorf("isTypeParamName(%+v, %q) = true, want false", typeParams, "K")
+	}
+}
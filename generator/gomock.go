@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/cv21/gen/pkg"
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+)
+
+const gomockPackage = "github.com/golang/mock/gomock"
+
+// gomockBackend renders golang/mock/gomock-style mocks: a struct holding a
+// *gomock.Controller plus a companion MockRecorder, with each method
+// forwarding the call to the controller and each recorder method returning
+// a *gomock.Call so expectations can be chained.
+type gomockBackend struct{}
+
+func (b *gomockBackend) Generate(f *File, params *generatorParams, mockStructName, interfaceName string, methods []*types.Function, typeParams []TypeParam) []pkg.GenerateResultFile {
+	recorderName := mockStructName + "MockRecorder"
+
+	f.Add(b.generateType(mockStructName, recorderName, interfaceName, typeParams)).Line()
+	f.Add(b.generateConstructor(mockStructName, recorderName, interfaceName, typeParams)).Line()
+	f.Add(b.generateExpect(mockStructName, recorderName, typeParams)).Line()
+
+	for _, method := range methods {
+		f.Add(b.generateMethod(params, mockStructName, method, typeParams)).Line()
+		f.Add(b.generateRecorderMethod(params, mockStructName, recorderName, method, typeParams)).Line()
+	}
+
+	return nil
+}
+
+// Generates the mock struct and its recorder. For example:
+//
+// // StringServiceMock is a mock of StringService interface.
+// type StringServiceMock struct {
+// 		ctrl     *gomock.Controller
+// 		recorder *StringServiceMockMockRecorder
+// }
+//
+// // StringServiceMockMockRecorder is the mock recorder for StringServiceMock.
+// type StringServiceMockMockRecorder struct {
+// 		mock *StringServiceMock
+// }
+func (b *gomockBackend) generateType(mockStructName, recorderName, interfaceName string, typeParams []TypeParam) *Statement {
+	declClause := typeParamsDeclClause(typeParams)
+	useClause := typeParamsUseClause(typeParams)
+
+	s := Commentf("%s is a mock of %s interface.", mockStructName, interfaceName).Line().
+		Type().Id(mockStructName)
+	if declClause != "" {
+		s = s.Op(declClause)
+	}
+	s = s.Struct(
+		Id("ctrl").Op("*").Qual(gomockPackage, "Controller"),
+		Id("recorder").Op("*").Id(recorderName).Op(useClause),
+	).Line().Line().
+		Commentf("%s is the mock recorder for %s.", recorderName, mockStructName).Line().
+		Type().Id(recorderName)
+	if declClause != "" {
+		s = s.Op(declClause)
+	}
+	return s.Struct(
+		Id("mock").Op("*").Id(mockStructName).Op(useClause),
+	)
+}
+
+// Generates the constructor. For example:
+//
+// // NewMockStringService creates a new mock instance.
+// func NewMockStringService(ctrl *gomock.Controller) *StringServiceMock {
+// 		mock := &StringServiceMock{ctrl: ctrl}
+// 		mock.recorder = &StringServiceMockMockRecorder{mock}
+// 		return mock
+// }
+func (b *gomockBackend) generateConstructor(mockStructName, recorderName, interfaceName string, typeParams []TypeParam) *Statement {
+	constructorName := fmt.Sprintf("NewMock%s", interfaceName)
+	declClause := typeParamsDeclClause(typeParams)
+	useClause := typeParamsUseClause(typeParams)
+
+	s := Commentf("%s creates a new mock instance.", constructorName).Line().
+		Func().Id(constructorName)
+	if declClause != "" {
+		s = s.Op(declClause)
+	}
+	return s.Params(Id("ctrl").Op("*").Qual(gomockPackage, "Controller")).Op("*").Id(mockStructName).Op(useClause).Block(
+		Id("mock").Op(":=").Op("&").Id(mockStructName).Op(useClause).Values(Dict{Id("ctrl"): Id("ctrl")}),
+		Id("mock.recorder").Op("=").Op("&").Id(recorderName).Op(useClause).Values(Id("mock")),
+		Return(Id("mock")),
+	)
+}
+
+// Generates the EXPECT accessor. For example:
+//
+// // EXPECT returns an object that allows the caller to indicate expected use.
+// func (_m *StringServiceMock) EXPECT() *StringServiceMockMockRecorder {
+// 		return _m.recorder
+// }
+func (b *gomockBackend) generateExpect(mockStructName, recorderName string, typeParams []TypeParam) *Statement {
+	useClause := typeParamsUseClause(typeParams)
+
+	return Comment("EXPECT returns an object that allows the caller to indicate expected use.").Line().
+		Func().Params(Id("_m").Op("*").Id(mockStructName).Op(useClause)).Id("EXPECT").Params().Op("*").Id(recorderName).Op(useClause).Block(
+		Return(Id("_m.recorder")),
+	)
+}
+
+// Generates method declaration. For example:
+//
+// // Concat mocks base method.
+// func (_m *StringServiceMock) Concat(a string, b string) string {
+// 		ret := _m.ctrl.Call(_m, "Concat", a, b)
+// 		ret0, _ := ret[0].(string)
+// 		return ret0
+// }
+func (b *gomockBackend) generateMethod(params *generatorParams, mockStructName string, method *types.Function, typeParams []TypeParam) *Statement {
+	return Comment(method.Name + " mocks base method.").Line().
+		Func().Params(Id("_m").Op("*").Id(mockStructName).Op(typeParamsUseClause(typeParams))).Id(method.Name).ParamsFunc(func(g *Group) {
+		for _, a := range method.Args {
+			g.Id(a.Name).Add(typeQual(params, typeParams, a.Type))
+		}
+	}).ParamsFunc(func(g *Group) {
+		for _, r := range method.Results {
+			g.Id(r.Name).Add(typeQual(params, typeParams, r.Type))
+		}
+	}).BlockFunc(func(g *Group) {
+		callStatement := func(g *Group) {
+			g.Id("_m.ctrl.Call").ParamsFunc(func(g *Group) {
+				g.Id("_m")
+				g.Lit(method.Name)
+				for _, a := range method.Args {
+					g.Id(a.Name)
+				}
+			})
+		}
+
+		if len(method.Results) == 0 {
+			g.CallFunc(callStatement)
+			return
+		}
+
+		g.Id("ret").Op(":=").CallFunc(callStatement)
+
+		// retNames are local to this method body, so they only need to avoid
+		// colliding with "ret" (the call's own result above) and with any
+		// named result the source interface declared explicitly - not with
+		// names synthesized elsewhere for other methods or embedded results.
+		usedRetNames := map[string]bool{"ret": true}
+		for _, r := range method.Results {
+			if r.Name != "" {
+				usedRetNames[r.Name] = true
+			}
+		}
+
+		var retNames []string
+		for i, r := range method.Results {
+			currentRetName := uniqueRetName(usedRetNames, i)
+			retNames = append(retNames, currentRetName)
+
+			g.List(Id(currentRetName), Id("_")).Op(":=").Id(fmt.Sprintf("ret[%d]", i)).Assert(typeQual(params, typeParams, r.Type))
+		}
+
+		g.ReturnFunc(func(g *Group) {
+			for _, retName := range retNames {
+				g.Add(Id(retName))
+			}
+		})
+	})
+}
+
+// uniqueRetName returns a "ret<i>"-shaped local variable name that doesn't
+// collide with any name in used, recording its choice in used.
+func uniqueRetName(used map[string]bool, i int) string {
+	candidate := fmt.Sprintf("ret%d", i)
+	for used[candidate] {
+		candidate += "_"
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// Generates the recorder method. For example:
+//
+// // Concat indicates an expected call of Concat.
+// func (_mr *StringServiceMockMockRecorder) Concat(a, b interface{}) *gomock.Call {
+// 		return _mr.mock.ctrl.RecordCall(_mr.mock, "Concat", a, b)
+// }
+func (b *gomockBackend) generateRecorderMethod(params *generatorParams, mockStructName, recorderName string, method *types.Function, typeParams []TypeParam) *Statement {
+	return Comment(method.Name + " indicates an expected call of " + method.Name + ".").Line().
+		Func().Params(Id("_mr").Op("*").Id(recorderName).Op(typeParamsUseClause(typeParams))).Id(method.Name).ParamsFunc(func(g *Group) {
+		for _, a := range method.Args {
+			g.Id(a.Name).Interface()
+		}
+	}).Op("*").Qual(gomockPackage, "Call").BlockFunc(func(g *Group) {
+		g.ReturnFunc(func(g *Group) {
+			g.Id("_mr.mock.ctrl.RecordCall").ParamsFunc(func(g *Group) {
+				g.Id("_mr.mock")
+				g.Lit(method.Name)
+				for _, a := range method.Args {
+					g.Id(a.Name)
+				}
+			})
+		})
+	})
+}
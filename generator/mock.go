@@ -16,8 +16,6 @@ const (
 	// It is useful for comments in generated files.
 	pluginRepoURL = "github.com/cv21/gen-generator-mock"
 	pluginVersion = "1.0.0"
-
-	mockPackage = "github.com/stretchr/testify/mock"
 )
 
 type (
@@ -44,6 +42,19 @@ type (
 		// It applies %s literal which holds interface name.
 		// Example: MyPrettyMockOf%s
 		MockStructNameTemplate string `json:"mock_struct_name_template"`
+
+		// It selects which mocking library the mock is generated for.
+		// One of BackendTestify or BackendGomock. Defaults to BackendTestify.
+		Backend string `json:"backend"`
+
+		// It selects how the interface is discovered.
+		// One of ModeSource or ModeReflect. Defaults to ModeSource.
+		Mode string `json:"mode"`
+
+		// It emits, for the testify backend, an On<Method>/Assert<Method>Called
+		// helper pair per interface method so expectations can be set up without
+		// the stringly-typed mock.On("Method", ...) call.
+		EmitTypedExpecters bool `json:"emit_typed_expecters"`
 	}
 
 	mockGenerator struct {
@@ -60,117 +71,61 @@ func (m *mockGenerator) Generate(p *pkg.GenerateParams) (*pkg.GenerateResult, er
 		return nil, err
 	}
 
-	iface := pkg.FindInterface(p.File, params.InterfaceName)
+	var (
+		interfaceName string
+		methods       []*types.Function
+		typeParams    []TypeParam
+		sourceFile    = p.File
+	)
 
-	f := NewFilePath(params.TargetPackagePath)
+	switch params.Mode {
+	case ModeReflect:
+		interfaceName = params.InterfaceName
+		sourceFile = &types.File{}
 
-	mockStructName := m.buildMockStructName(params.MockStructNameTemplate, iface.Name)
+		methods, err = generateMethodsViaReflect(params.SourcePackagePath, interfaceName)
+		if err != nil {
+			return nil, fmt.Errorf("reflect on %s: %w", interfaceName, err)
+		}
+	case ModeSource, "":
+		iface := pkg.FindInterface(p.File, params.InterfaceName)
+		interfaceName = iface.Name
 
-	f.Add(m.generateType(mockStructName, iface.Name)).Line()
+		typeParams, err = resolveTypeParams(params.SourcePackagePath, interfaceName)
+		if err != nil {
+			m.logger.Warn("resolving type parameters, continuing as non-generic", "interface", interfaceName, "error", err)
+		}
 
-	for _, method := range iface.Methods {
-		f.Add(m.generateMethod(params, iface.Name, mockStructName, method)).Line()
+		methods, err = resolveMethods(params.SourcePackagePath, iface)
+		if err != nil {
+			m.logger.Warn("resolving embedded interfaces, using directly declared methods only", "interface", interfaceName, "error", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown mode %q", params.Mode)
 	}
 
-	return &pkg.GenerateResult{
-		Files: []pkg.GenerateResultFile{
-			{
-				Path:    fmt.Sprintf(params.OutPathTemplate, strcase.ToSnake(iface.Name)),
-				Content: []byte(fmt.Sprintf("%#v", pkg.AddDefaultPackageComment(f, pluginRepoURL, pluginVersion))),
-			},
-		},
-	}, nil
-}
+	reg := newRegistry(sourceFile)
+	reg.scanMethods(methods)
 
-// Generates type declaration. For example:
-//
-// // StringServiceMock is an autogenerated mock type for the StringService interface.
-// type StringServiceMock struct {
-// 		mock.Mock
-// }
-func (m *mockGenerator) generateType(mockStructName, interfaceName string) *Statement {
-	return Commentf("%s is an autogenerated mock type for the %s interface.", mockStructName, interfaceName).Line().
-		Type().Id(mockStructName).Struct(
-		Qual(mockPackage, "Mock"),
-	)
-}
+	f := NewFilePath(params.TargetPackagePath)
+	for importPath, alias := range reg.aliasByPackage {
+		f.ImportAlias(importPath, alias)
+	}
 
-// Generates method declaration. For example:
-//
-// // Concat provides a mock function for method Concat of interface StringService.
-// func (_m *StringServiceMock) Concat(a string, b string) string {
-//		ret := _m.Called(a, b)
-//
-//		var r0 string
-//		if rf, ok := ret.Get(0).(func(string, string) string); ok {
-//			r0 = rf(a, b)
-//		} else {
-//			r0 = ret.Get(0).(string)
-//		}
-//
-//		return r0
-// }
-//
-func (m *mockGenerator) generateMethod(params *generatorParams, interfaceName, mockStructName string, method *types.Function) *Statement {
-	return Commentf("%s provides a mock function for method %s of interface %s.", method.Name, method.Name, interfaceName).Line().
-		Func().Params(Id("_m").Id(fmt.Sprintf("*%s", mockStructName))).Id(method.Name).ParamsFunc(func(g *Group) {
-		for _, a := range method.Args {
-			g.Id(a.Name).Add(typeQual(params, a.Type))
-		}
-	}).ParamsFunc(func(g *Group) {
-		for _, r := range method.Results {
-			g.Id(r.Name).Add(typeQual(params, r.Type))
-		}
-	}).BlockFunc(func(g *Group) {
-		g.Id("ret").Op(":=").Id("_m.Called").ParamsFunc(func(g *Group) {
-			for _, a := range method.Args {
-				g.Id(a.Name)
-			}
-		}).Line()
-
-		var retNames []string
-		for i, r := range method.Results {
-			currentRetName := fmt.Sprintf("r%d", i)
-			retNames = append(retNames, currentRetName)
-
-			g.Var().Id(currentRetName).Add(typeQual(params, r.Type))
-			g.If(List(Id("rf"), Id("ok").Op(":=").Id("ret.Get").Call(Lit(i))).Assert(Func().ParamsFunc(func(g *Group) {
-				for _, a := range method.Args {
-					g.Add(typeQual(params, a.Type))
-				}
-			}).Add(typeQual(params, r.Type))), Id("ok")).BlockFunc(func(g *Group) {
-				g.Id(currentRetName).Op("=").Id("rf").ParamsFunc(func(g *Group) {
-					for _, a := range method.Args {
-						g.Id(a.Name)
-					}
-				})
-			}).Else().BlockFunc(func(g *Group) {
-				if pkg.IsErrorType(r.Type) {
-					// 		r0 = ret.Error(0)
-					g.Id(currentRetName).Op("=").Id("ret.Error").Params(Lit(i))
-				} else {
-					if pkg.IsNillableType(r.Type) {
-						// 		if ret.Get(0) != nil {
-						//			r0 = ret.Get(0).(*bla.Bla)
-						//		}
-						g.If(Id("ret.Get").Params(Lit(i)).Op("!=").Nil()).BlockFunc(func(g *Group) {
-							g.Add(Id(currentRetName).Op("=").Id("ret.Get").Params(Lit(i)).Assert(typeQual(params, r.Type)))
-						})
-					} else {
-						// 		r0 = ret.Get(0).(*bla.Bla)
-						g.Id(currentRetName).Op("=").Id("ret.Get").Params(Lit(i)).Assert(typeQual(params, r.Type))
-					}
-
-				}
-			}).Line()
-		}
+	mockStructName := m.buildMockStructName(params.MockStructNameTemplate, interfaceName)
+
+	extraFiles := backendFor(params).Generate(f, params, mockStructName, interfaceName, methods, typeParams)
 
-		g.ReturnFunc(func(g *Group) {
-			for _, retName := range retNames {
-				g.Add(Id(retName))
-			}
-		})
-	})
+	files := append([]pkg.GenerateResultFile{
+		{
+			Path:    fmt.Sprintf(params.OutPathTemplate, strcase.ToSnake(interfaceName)),
+			Content: []byte(fmt.Sprintf("%#v", pkg.AddDefaultPackageComment(f, pluginRepoURL, pluginVersion))),
+		},
+	}, extraFiles...)
+
+	return &pkg.GenerateResult{
+		Files: files,
+	}, nil
 }
 
 // Returns a mock structure name by given interfaceName.
@@ -181,11 +136,6 @@ func (m *mockGenerator) buildMockStructName(template string, interfaceName strin
 	return fmt.Sprintf(template, interfaceName)
 }
 
-// It is a convenient func for calling pkg.TypeQual.
-func typeQual(params *generatorParams, t types.Type) *Statement {
-	return pkg.TypeQual(params.SourcePackagePath, params.TargetPackagePath, t)
-}
-
 // Allocates and returns new structure of mockGenerator.
 func NewGenerator() pkg.Generator {
 	return &mockGenerator{
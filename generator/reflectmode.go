@@ -0,0 +1,252 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/vetcher/go-astra/types"
+)
+
+const (
+	// ModeSource parses the interface from its Go source with go-astra. This is the default.
+	ModeSource = "source"
+
+	// ModeReflect resolves the interface's method set at runtime via reflection instead of
+	// parsing source. Use this for interfaces go-astra can't parse: cgo, go:build-gated
+	// files, or vendored/binary-only dependencies.
+	ModeReflect = "reflect"
+)
+
+// generateMethodsViaReflect resolves interfaceName's method set by compiling
+// and running a small bootstrap program that reflects on the interface and
+// prints its method set as JSON, following the same approach gomock's own
+// reflect mode uses. It's the only way to mock an interface go-astra can't
+// parse, since it never looks at the source at all.
+func generateMethodsViaReflect(sourcePackagePath, interfaceName string) ([]*types.Function, error) {
+	dir, err := os.MkdirTemp("", "gen-generator-mock-reflect")
+	if err != nil {
+		return nil, fmt.Errorf("create reflect bootstrap dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	progPath := filepath.Join(dir, "main.go")
+	if err := writeReflectProgram(progPath, sourcePackagePath, interfaceName); err != nil {
+		return nil, fmt.Errorf("write reflect bootstrap program: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "run", progPath)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run reflect bootstrap program: %w: %s", err, stderr.String())
+	}
+
+	var reflected reflectedInterface
+	if err := json.Unmarshal(stdout.Bytes(), &reflected); err != nil {
+		return nil, fmt.Errorf("decode reflect bootstrap output: %w", err)
+	}
+
+	methods := make([]*types.Function, 0, len(reflected.Methods))
+	for _, method := range reflected.Methods {
+		methods = append(methods, method.toFunction())
+	}
+	return methods, nil
+}
+
+// reflectedInterface is the JSON shape printed by the bootstrap program.
+type reflectedInterface struct {
+	Methods []reflectedMethod `json:"methods"`
+}
+
+type reflectedMethod struct {
+	Name    string           `json:"name"`
+	Args    []reflectedParam `json:"args"`
+	Results []reflectedParam `json:"results"`
+}
+
+func (m reflectedMethod) toFunction() *types.Function {
+	args := make([]types.Variable, 0, len(m.Args))
+	for _, a := range m.Args {
+		args = append(args, a.toVariable())
+	}
+
+	results := make([]types.Variable, 0, len(m.Results))
+	for _, r := range m.Results {
+		results = append(results, r.toVariable())
+	}
+
+	return &types.Function{
+		Base:    types.Base{Name: m.Name},
+		Args:    args,
+		Results: results,
+	}
+}
+
+type reflectedParam struct {
+	Name string        `json:"name"`
+	Type reflectedType `json:"type"`
+}
+
+func (p reflectedParam) toVariable() types.Variable {
+	return types.Variable{Base: types.Base{Name: p.Name}, Type: p.Type.toType()}
+}
+
+// reflectedType mirrors the recursive shape needed to rebuild a go-astra
+// types.Type from what reflect.Type can tell us: a kind, its element/key
+// types (for pointers, slices, arrays, maps) and, for named types, its
+// name and import path. Variadic marks a slice produced from the last
+// parameter of a variadic method, so it round-trips as `...T` rather than
+// `[]T`. Anonymous marks a type with no name of its own (e.g. a bare
+// `interface{}` parameter), so it round-trips as `interface{}` rather than
+// a blank named type.
+type reflectedType struct {
+	Kind      string         `json:"kind"`
+	Name      string         `json:"name,omitempty"`
+	PkgPath   string         `json:"pkg_path,omitempty"`
+	Elem      *reflectedType `json:"elem,omitempty"`
+	Key       *reflectedType `json:"key,omitempty"`
+	Variadic  bool           `json:"variadic,omitempty"`
+	Anonymous bool           `json:"anonymous,omitempty"`
+}
+
+func (t reflectedType) toType() types.Type {
+	switch t.Kind {
+	case "ptr":
+		return types.TPointer{Next: t.Elem.toType()}
+	case "slice":
+		if t.Variadic {
+			return types.TEllipsis{Next: t.Elem.toType()}
+		}
+		return types.TArray{IsSlice: true, Next: t.Elem.toType()}
+	case "array":
+		return types.TArray{Next: t.Elem.toType()}
+	case "map":
+		return types.TMap{Key: t.Key.toType(), Value: t.Elem.toType()}
+	case "interface":
+		if t.Anonymous {
+			return types.TName{TypeName: "interface{}"}
+		}
+		fallthrough
+	case "named":
+		if t.PkgPath == "" {
+			return types.TName{TypeName: t.Name}
+		}
+		return types.TImport{
+			Import: &types.Import{Package: t.PkgPath},
+			Next:   types.TName{TypeName: t.Name},
+		}
+	default:
+		return types.TName{TypeName: t.Name}
+	}
+}
+
+const reflectProgramTmpl = `// Code generated by gen-generator-mock reflect mode. DO NOT EDIT.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	target {{printf "%q" .SourcePackagePath}}
+)
+
+type reflectedType struct {
+	Kind      string         ` + "`json:\"kind\"`" + `
+	Name      string         ` + "`json:\"name,omitempty\"`" + `
+	PkgPath   string         ` + "`json:\"pkg_path,omitempty\"`" + `
+	Elem      *reflectedType ` + "`json:\"elem,omitempty\"`" + `
+	Key       *reflectedType ` + "`json:\"key,omitempty\"`" + `
+	Variadic  bool           ` + "`json:\"variadic,omitempty\"`" + `
+	Anonymous bool           ` + "`json:\"anonymous,omitempty\"`" + `
+}
+
+func convertType(t reflect.Type) *reflectedType {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return &reflectedType{Kind: "ptr", Elem: convertType(t.Elem())}
+	case reflect.Slice:
+		return &reflectedType{Kind: "slice", Elem: convertType(t.Elem())}
+	case reflect.Array:
+		return &reflectedType{Kind: "array", Elem: convertType(t.Elem())}
+	case reflect.Map:
+		return &reflectedType{Kind: "map", Key: convertType(t.Key()), Elem: convertType(t.Elem())}
+	case reflect.Interface:
+		return &reflectedType{Kind: "interface", Name: t.Name(), PkgPath: t.PkgPath(), Anonymous: t.Name() == ""}
+	default:
+		return &reflectedType{Kind: "named", Name: t.Name(), PkgPath: t.PkgPath()}
+	}
+}
+
+type reflectedParam struct {
+	Name string         ` + "`json:\"name\"`" + `
+	Type reflectedType  ` + "`json:\"type\"`" + `
+}
+
+type reflectedMethod struct {
+	Name    string           ` + "`json:\"name\"`" + `
+	Args    []reflectedParam ` + "`json:\"args\"`" + `
+	Results []reflectedParam ` + "`json:\"results\"`" + `
+}
+
+func main() {
+	ifaceType := reflect.TypeOf((*target.{{.InterfaceName}})(nil)).Elem()
+
+	methods := make([]reflectedMethod, 0, ifaceType.NumMethod())
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		m := ifaceType.Method(i)
+		fnType := m.Type
+
+		args := make([]reflectedParam, 0, fnType.NumIn())
+		for j := 0; j < fnType.NumIn(); j++ {
+			argType := convertType(fnType.In(j))
+			if fnType.IsVariadic() && j == fnType.NumIn()-1 {
+				argType.Variadic = true
+			}
+			args = append(args, reflectedParam{Name: fmt.Sprintf("arg%d", j), Type: *argType})
+		}
+
+		results := make([]reflectedParam, 0, fnType.NumOut())
+		for j := 0; j < fnType.NumOut(); j++ {
+			results = append(results, reflectedParam{Name: fmt.Sprintf("ret%d", j), Type: *convertType(fnType.Out(j))})
+		}
+
+		methods = append(methods, reflectedMethod{Name: m.Name, Args: args, Results: results})
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(struct {
+		Methods []reflectedMethod ` + "`json:\"methods\"`" + `
+	}{Methods: methods}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`
+
+func writeReflectProgram(path, sourcePackagePath, interfaceName string) error {
+	tmpl, err := template.New("reflect-bootstrap").Parse(reflectProgramTmpl)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return tmpl.Execute(f, struct {
+		SourcePackagePath string
+		InterfaceName     string
+	}{
+		SourcePackagePath: sourcePackagePath,
+		InterfaceName:     interfaceName,
+	})
+}
@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/vetcher/go-astra/types"
+)
+
+func TestGenerateMethodsViaReflect(t *testing.T) {
+	methods, err := generateMethodsViaReflect("github.com/cv21/gen-generator-mock/generator/testdata/reflectfixture", "Logger")
+	if err != nil {
+		t.Fatalf("generateMethodsViaReflect: %v", err)
+	}
+
+	byName := make(map[string]*types.Function, len(methods))
+	for _, m := range methods {
+		byName[m.Name] = m
+	}
+
+	printf, ok := byName["Printf"]
+	if !ok {
+		t.Fatalf("Printf not found in %+v", byName)
+	}
+	if len(printf.Args) != 2 {
+		t.Fatalf("Printf has %d args, want 2: %+v", len(printf.Args), printf.Args)
+	}
+	variadicArg := printf.Args[1].Type
+	ellipsis, ok := variadicArg.(types.TEllipsis)
+	if !ok {
+		t.Fatalf("Printf's variadic arg has type %#v, want types.TEllipsis", variadicArg)
+	}
+	if name, ok := ellipsis.Next.(types.TName); !ok || name.TypeName != "int" {
+		t.Errorf("Printf's variadic arg element = %#v, want types.TName{TypeName: \"int\"}", ellipsis.Next)
+	}
+
+	handle, ok := byName["Handle"]
+	if !ok {
+		t.Fatalf("Handle not found in %+v", byName)
+	}
+	if len(handle.Args) != 1 {
+		t.Fatalf("Handle has %d args, want 1: %+v", len(handle.Args), handle.Args)
+	}
+	anonArg := handle.Args[0].Type
+	if name, ok := anonArg.(types.TName); !ok || name.TypeName != "interface{}" {
+		t.Errorf("Handle's interface{} arg = %#v, want types.TName{TypeName: \"interface{}\"}", anonArg)
+	}
+}
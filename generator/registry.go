@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/iancoleman/strcase"
+	"github.com/vetcher/go-astra/types"
+)
+
+// registry assigns stable, collision-free import aliases for package paths
+// referenced by an interface's methods, and synthesizes a unique, readable
+// name for any parameter the source interface declared anonymously. It
+// follows the same approach moq's internal/registry package takes.
+type registry struct {
+	aliasByPackage map[string]string
+	usedAliases    map[string]bool
+}
+
+// newRegistry seeds the registry with the aliases already present in the
+// source file's import block, so generated code reuses the alias the user
+// already chose instead of inventing a different one for the same package.
+func newRegistry(file *types.File) *registry {
+	r := &registry{
+		aliasByPackage: map[string]string{},
+		usedAliases:    map[string]bool{},
+	}
+
+	for _, imp := range file.Imports {
+		alias := imp.Name
+		if alias == "" {
+			alias = path.Base(imp.Package)
+		}
+		r.aliasByPackage[imp.Package] = alias
+		r.usedAliases[alias] = true
+	}
+
+	return r
+}
+
+// scanMethods walks every argument and result type of methods, assigning an
+// alias for any package path it hasn't seen yet and a unique name for any
+// parameter declared anonymously in the source interface. Synthesized names
+// only need to be unique within a single method's signature, so
+// usedParamNames is scoped per method rather than shared across the whole
+// interface - otherwise two unrelated methods with, say, a trailing
+// anonymous error result would get diverging names (err, err2, err3, ...)
+// purely from call order.
+func (r *registry) scanMethods(methods []*types.Function) {
+	for _, method := range methods {
+		usedParamNames := map[string]bool{}
+		seedUsedParamNames(usedParamNames, method.Args)
+		seedUsedParamNames(usedParamNames, method.Results)
+
+		r.scanVariables(usedParamNames, method.Args)
+		r.scanVariables(usedParamNames, method.Results)
+	}
+}
+
+// seedUsedParamNames pre-populates usedParamNames with every name the source
+// interface already assigned explicitly, so a name synthesized for an
+// anonymous parameter can't collide with one declared in the same method -
+// e.g. Get(s string) (string, error) must not synthesize "s" again for its
+// anonymous string result.
+func seedUsedParamNames(usedParamNames map[string]bool, vars []types.Variable) {
+	for _, v := range vars {
+		if v.Name != "" {
+			usedParamNames[v.Name] = true
+		}
+	}
+}
+
+func (r *registry) scanVariables(usedParamNames map[string]bool, vars []types.Variable) {
+	for i := range vars {
+		walkType(vars[i].Type, func(importPath string) {
+			r.aliasFor(importPath)
+		})
+
+		if vars[i].Name == "" {
+			vars[i].Name = uniqueParamName(usedParamNames, vars[i].Type)
+		}
+	}
+}
+
+// aliasFor returns the alias to use for importPath, assigning and
+// remembering a new, collision-free one on first use.
+func (r *registry) aliasFor(importPath string) string {
+	if alias, ok := r.aliasByPackage[importPath]; ok {
+		return alias
+	}
+
+	base := path.Base(importPath)
+	alias := base
+	for n := 2; r.usedAliases[alias]; n++ {
+		alias = fmt.Sprintf("%s%d", base, n)
+	}
+
+	r.aliasByPackage[importPath] = alias
+	r.usedAliases[alias] = true
+	return alias
+}
+
+// uniqueParamName derives a readable parameter name from t (e.g. "string" ->
+// "s", "[]Foo" -> "foos", "map[string]int" -> "stringToInt") and
+// disambiguates it against any name already used in usedParamNames, which
+// callers scope to a single method's signature.
+func uniqueParamName(usedParamNames map[string]bool, t types.Type) string {
+	name := paramNameHint(t)
+
+	candidate := name
+	for n := 2; usedParamNames[candidate]; n++ {
+		candidate = fmt.Sprintf("%s%d", name, n)
+	}
+	usedParamNames[candidate] = true
+	return candidate
+}
+
+// walkType calls visit with the import path of every package referenced by
+// t, including nested types such as slices, maps and pointers.
+func walkType(t types.Type, visit func(importPath string)) {
+	switch t := t.(type) {
+	case types.TImport:
+		if t.Import != nil {
+			visit(t.Import.Package)
+		}
+		walkType(t.Next, visit)
+	case types.TPointer:
+		walkType(t.Next, visit)
+	case types.TArray:
+		walkType(t.Next, visit)
+	case types.TEllipsis:
+		walkType(t.Next, visit)
+	case types.TMap:
+		walkType(t.Key, visit)
+		walkType(t.Value, visit)
+	}
+}
+
+var builtinParamAbbreviations = map[string]string{
+	"string":  "s",
+	"bool":    "b",
+	"int":     "i",
+	"int8":    "i",
+	"int16":   "i",
+	"int32":   "i",
+	"int64":   "i",
+	"uint":    "u",
+	"uint8":   "u",
+	"uint16":  "u",
+	"uint32":  "u",
+	"uint64":  "u",
+	"float32": "f",
+	"float64": "f",
+	"byte":    "b",
+	"rune":    "r",
+	"error":   "err",
+}
+
+// paramNameHint derives a short, idiomatic parameter name for t.
+func paramNameHint(t types.Type) string {
+	if tn, ok := t.(types.TName); ok {
+		if abbr, ok := builtinParamAbbreviations[tn.TypeName]; ok {
+			return abbr
+		}
+	}
+	return readableTypeName(t)
+}
+
+// readableTypeName spells out a type as a name, e.g. []Foo -> "foos" and
+// map[string]int -> "stringToInt", without abbreviating scalar types.
+func readableTypeName(t types.Type) string {
+	switch t := t.(type) {
+	case types.TName:
+		return strcase.ToLowerCamel(t.TypeName)
+	case types.TImport:
+		return readableTypeName(t.Next)
+	case types.TPointer:
+		return readableTypeName(t.Next)
+	case types.TEllipsis:
+		return readableTypeName(t.Next) + "s"
+	case types.TArray:
+		return readableTypeName(t.Next) + "s"
+	case types.TMap:
+		return readableTypeName(t.Key) + "To" + strcase.ToCamel(readableTypeName(t.Value))
+	default:
+		return "v"
+	}
+}
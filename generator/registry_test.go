@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/vetcher/go-astra/types"
+)
+
+// TestScanMethodsAvoidsCollisionWithExplicitNames reproduces Get(s string)
+// (string, error): the anonymous string result must not synthesize "s"
+// again just because it's the first name tried for a string.
+func TestScanMethodsAvoidsCollisionWithExplicitNames(t *testing.T) {
+	method := &types.Function{
+		Base: types.Base{Name: "Get"},
+		Args: []types.Variable{
+			{Base: types.Base{Name: "s"}, Type: types.TName{TypeName: "string"}},
+		},
+		Results: []types.Variable{
+			{Type: types.TName{TypeName: "string"}},
+			{Type: types.TName{TypeName: "error"}},
+		},
+	}
+
+	r := newRegistry(&types.File{})
+	r.scanMethods([]*types.Function{method})
+
+	if method.Args[0].Name != "s" {
+		t.Fatalf("Args[0].Name = %q, want %q", method.Args[0].Name, "s")
+	}
+	if method.Results[0].Name == "s" {
+		t.Fatalf("Results[0].Name = %q, collides with explicitly-named arg %q", method.Results[0].Name, "s")
+	}
+	if method.Results[0].Name == "" || method.Results[1].Name == "" {
+		t.Fatalf("results should have synthesized names, got %+v", method.Results)
+	}
+	if method.Results[0].Name == method.Results[1].Name {
+		t.Fatalf("Results have colliding names %q", method.Results[0].Name)
+	}
+}
@@ -0,0 +1,16 @@
+// Package embediface is a fixture used by embedded_test.go to exercise
+// resolveMethods against a real interface that embeds another before
+// declaring its own methods.
+package embediface
+
+import "io"
+
+// Store embeds io.Closer before its own methods, so a naive "promoted
+// methods always come last" merge would order Close after Get/Put even
+// though it's declared first.
+type Store interface {
+	io.Closer
+
+	Get(key string) (string, error)
+	Put(key, value string) error
+}
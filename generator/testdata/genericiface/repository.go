@@ -0,0 +1,16 @@
+// Package genericiface is a fixture used by generics_test.go to exercise
+// resolveTypeParams against a real generics-bearing interface. It lives under
+// testdata so the go tool never tries to build or vet it on its own.
+package genericiface
+
+// Repository is a generic, Repository[T any]-style interface.
+type Repository[T any] interface {
+	Get(id string) (T, error)
+	Save(v T) error
+}
+
+// Pinger is a plain, non-generic interface, used to confirm
+// resolveTypeParams returns nil rather than an error for it.
+type Pinger interface {
+	Ping() error
+}
@@ -0,0 +1,10 @@
+// Package reflectfixture is a fixture used by reflectmode_test.go to exercise
+// generateMethodsViaReflect end to end against a real compiled program.
+package reflectfixture
+
+// Logger has a variadic parameter and a bare interface{} parameter, the two
+// shapes reflect mode has to special-case.
+type Logger interface {
+	Printf(format string, args ...int)
+	Handle(v interface{}) error
+}
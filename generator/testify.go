@@ -0,0 +1,278 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cv21/gen/pkg"
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+)
+
+const testifyMockPackage = "github.com/stretchr/testify/mock"
+
+// matcherFileName is the fixed name the Matcher helper scaffolding is written
+// under, alongside the generated mocks, so repeated Generate calls into the
+// same output directory can detect it's already there.
+const matcherFileName = "matcher_gen.go"
+
+// testifyBackend renders stretchr/testify/mock-style mocks: a struct embedding
+// mock.Mock, with each method forwarding to _m.Called and unpacking results
+// out of the returned mock.Arguments.
+type testifyBackend struct{}
+
+func (b *testifyBackend) Generate(f *File, params *generatorParams, mockStructName, interfaceName string, methods []*types.Function, typeParams []TypeParam) []pkg.GenerateResultFile {
+	var extra []pkg.GenerateResultFile
+
+	if params.EmitTypedExpecters {
+		if matcherFile, ok := b.generateMatcherFile(params); ok {
+			extra = append(extra, matcherFile)
+		}
+	}
+
+	f.Add(b.generateType(mockStructName, interfaceName, typeParams)).Line()
+
+	for _, method := range methods {
+		f.Add(b.generateMethod(params, interfaceName, mockStructName, method, typeParams)).Line()
+
+		// Matcher[T] has no valid instantiation for a variadic parameter's
+		// element type in bracket position (Matcher[...int] isn't legal Go),
+		// so methods with a variadic parameter don't get typed expecters.
+		if params.EmitTypedExpecters && !hasVariadicArg(method) {
+			f.Add(b.generateTypedOn(params, mockStructName, method, typeParams)).Line()
+			f.Add(b.generateTypedAssertCalled(params, mockStructName, method, typeParams)).Line()
+		}
+	}
+
+	return extra
+}
+
+// hasVariadicArg reports whether method has a variadic parameter.
+func hasVariadicArg(method *types.Function) bool {
+	for _, a := range method.Args {
+		if _, ok := a.Type.(types.TEllipsis); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// generateMatcherFile renders the Matcher/Is/AnyOf scaffolding into its own
+// file under the same directory as the mock's OutPathTemplate, so mocking two
+// or more interfaces with EmitTypedExpecters into the same TargetPackagePath
+// doesn't produce duplicate Matcher/Is/AnyOf declarations: it's emitted at
+// most once, the first time a mock is generated into that directory, and
+// skipped (ok is false) once matcherFileName is already there.
+func (b *testifyBackend) generateMatcherFile(params *generatorParams) (file pkg.GenerateResultFile, ok bool) {
+	path := filepath.Join(filepath.Dir(params.OutPathTemplate), matcherFileName)
+	if _, err := os.Stat(path); err == nil {
+		return pkg.GenerateResultFile{}, false
+	}
+
+	f := NewFilePath(params.TargetPackagePath)
+	f.Add(b.generateMatcherType())
+
+	return pkg.GenerateResultFile{
+		Path:    path,
+		Content: []byte(fmt.Sprintf("%#v", pkg.AddDefaultPackageComment(f, pluginRepoURL, pluginVersion))),
+	}, true
+}
+
+// Generates type declaration. For example:
+//
+// // StringServiceMock is an autogenerated mock type for the StringService interface.
+// type StringServiceMock struct {
+// 		mock.Mock
+// }
+//
+// For a generic interface, the type parameters are carried over onto the
+// mock, e.g. `type RepositoryMock[T any] struct { mock.Mock }`.
+func (b *testifyBackend) generateType(mockStructName, interfaceName string, typeParams []TypeParam) *Statement {
+	s := Commentf("%s is an autogenerated mock type for the %s interface.", mockStructName, interfaceName).Line().
+		Type().Id(mockStructName)
+	if clause := typeParamsDeclClause(typeParams); clause != "" {
+		s = s.Op(clause)
+	}
+	return s.Struct(
+		Qual(testifyMockPackage, "Mock"),
+	)
+}
+
+// Generates method declaration. For example:
+//
+// // Concat provides a mock function for method Concat of interface StringService.
+// func (_m *StringServiceMock) Concat(a string, b string) string {
+//		ret := _m.Called(a, b)
+//
+//		var r0 string
+//		if rf, ok := ret.Get(0).(func(string, string) string); ok {
+//			r0 = rf(a, b)
+//		} else {
+//			r0 = ret.Get(0).(string)
+//		}
+//
+//		return r0
+// }
+//
+func (b *testifyBackend) generateMethod(params *generatorParams, interfaceName, mockStructName string, method *types.Function, typeParams []TypeParam) *Statement {
+	return Commentf("%s provides a mock function for method %s of interface %s.", method.Name, method.Name, interfaceName).Line().
+		Func().Params(Id("_m").Id(fmt.Sprintf("*%s%s", mockStructName, typeParamsUseClause(typeParams)))).Id(method.Name).ParamsFunc(func(g *Group) {
+		for _, a := range method.Args {
+			g.Id(a.Name).Add(typeQual(params, typeParams, a.Type))
+		}
+	}).ParamsFunc(func(g *Group) {
+		for _, r := range method.Results {
+			g.Id(r.Name).Add(typeQual(params, typeParams, r.Type))
+		}
+	}).BlockFunc(func(g *Group) {
+		g.Id("ret").Op(":=").Id("_m.Called").ParamsFunc(func(g *Group) {
+			for _, a := range method.Args {
+				g.Id(a.Name)
+			}
+		}).Line()
+
+		var retNames []string
+		for i, r := range method.Results {
+			currentRetName := fmt.Sprintf("r%d", i)
+			retNames = append(retNames, currentRetName)
+
+			g.Var().Id(currentRetName).Add(typeQual(params, typeParams, r.Type))
+			g.If(List(Id("rf"), Id("ok").Op(":=").Id("ret.Get").Call(Lit(i))).Assert(Func().ParamsFunc(func(g *Group) {
+				for _, a := range method.Args {
+					g.Add(typeQual(params, typeParams, a.Type))
+				}
+			}).Add(typeQual(params, typeParams, r.Type))), Id("ok")).BlockFunc(func(g *Group) {
+				g.Id(currentRetName).Op("=").Id("rf").ParamsFunc(func(g *Group) {
+					for _, a := range method.Args {
+						g.Id(a.Name)
+					}
+				})
+			}).Else().BlockFunc(func(g *Group) {
+				if pkg.IsErrorType(r.Type) {
+					// 		r0 = ret.Error(0)
+					g.Id(currentRetName).Op("=").Id("ret.Error").Params(Lit(i))
+				} else {
+					if pkg.IsNillableType(r.Type) {
+						// 		if ret.Get(0) != nil {
+						//			r0 = ret.Get(0).(*bla.Bla)
+						//		}
+						g.If(Id("ret.Get").Params(Lit(i)).Op("!=").Nil()).BlockFunc(func(g *Group) {
+							g.Add(Id(currentRetName).Op("=").Id("ret.Get").Params(Lit(i)).Assert(typeQual(params, typeParams, r.Type)))
+						})
+					} else {
+						// 		r0 = ret.Get(0).(*bla.Bla)
+						g.Id(currentRetName).Op("=").Id("ret.Get").Params(Lit(i)).Assert(typeQual(params, typeParams, r.Type))
+					}
+
+				}
+			}).Line()
+		}
+
+		g.ReturnFunc(func(g *Group) {
+			for _, retName := range retNames {
+				g.Add(Id(retName))
+			}
+		})
+	})
+}
+
+// Generates the Matcher helper type shared by every typed expecter method.
+// For example:
+//
+// // Matcher is either a concrete expected value or an AnyOf placeholder,
+// // for use with the On<Method>/Assert<Method>Called helpers.
+// type Matcher[T any] struct {
+// 		value   T
+// 		anyType bool
+// }
+func (b *testifyBackend) generateMatcherType() *Statement {
+	return Comment("Matcher is either a concrete expected value or an AnyOf placeholder,").Line().
+		Comment("for use with the On<Method>/Assert<Method>Called helpers.").Line().
+		Type().Id("Matcher").Op("[T any]").Struct(
+		Id("value").Id("T"),
+		Id("anyType").Bool(),
+	).Line().Line().
+		Comment("Is builds a Matcher that expects value exactly.").Line().
+		Func().Id("Is").Op("[T any]").Params(Id("value").Id("T")).Id("Matcher").Op("[T]").Block(
+		Return(Id("Matcher").Op("[T]").Values(Dict{Id("value"): Id("value")})),
+	).Line().Line().
+		Comment("AnyOf builds a Matcher that accepts any value of type T.").Line().
+		Func().Id("AnyOf").Op("[T any]").Params().Id("Matcher").Op("[T]").Block(
+		Return(Id("Matcher").Op("[T]").Values(Dict{Id("anyType"): True()})),
+	).Line().Line().
+		Comment("arg returns the value to pass to testify's On/AssertCalled.").Line().
+		Func().Params(Id("m").Id("Matcher").Op("[T]")).Id("arg").Params().Interface().Block(
+		If(Id("m.anyType")).Block(
+			// reflect.TypeOf(&m.value).Elem() is used instead of
+			// reflect.TypeOf(m.value) because the latter reports the dynamic
+			// type of the zero value, which for an interface-typed T is nil
+			// regardless of T - the pointer's element type is always T itself.
+			Id("t").Op(":=").Qual("reflect", "TypeOf").Call(Op("&").Id("m.value")).Dot("Elem").Call(),
+			If(Id("t.Kind").Call().Op("==").Qual("reflect", "Interface")).Block(
+				// mock.Arguments.Diff matches AnythingOfType against the real
+				// argument's dynamic type, which varies across an interface
+				// T's possible implementations, so AnythingOfType(T's static
+				// name) would never match. mock.Anything matches any value.
+				Return(Qual(testifyMockPackage, "Anything")),
+			),
+			Return(Qual(testifyMockPackage, "AnythingOfType").Call(Id("t.String").Call())),
+		),
+		Return(Id("m.value")),
+	)
+}
+
+// matcherType renders the Matcher[T] instantiation for a parameter of type t.
+func matcherType(params *generatorParams, typeParams []TypeParam, t types.Type) *Statement {
+	return Id("Matcher").Op("[").Add(typeQual(params, typeParams, t)).Op("]")
+}
+
+// Generates the typed On helper. For example:
+//
+// // OnConcat sets up a typed expectation for Concat, returning the chainable *mock.Call.
+// func (_m *StringServiceMock) OnConcat(a Matcher[string], b Matcher[string]) *mock.Call {
+// 		return _m.On("Concat", a.arg(), b.arg())
+// }
+func (b *testifyBackend) generateTypedOn(params *generatorParams, mockStructName string, method *types.Function, typeParams []TypeParam) *Statement {
+	onName := "On" + method.Name
+
+	return Commentf("%s sets up a typed expectation for %s, returning the chainable *mock.Call.", onName, method.Name).Line().
+		Func().Params(Id("_m").Id(fmt.Sprintf("*%s%s", mockStructName, typeParamsUseClause(typeParams)))).Id(onName).ParamsFunc(func(g *Group) {
+		for _, a := range method.Args {
+			g.Id(a.Name).Add(matcherType(params, typeParams, a.Type))
+		}
+	}).Op("*").Qual(testifyMockPackage, "Call").BlockFunc(func(g *Group) {
+		g.Return(Id("_m.On").CallFunc(func(g *Group) {
+			g.Lit(method.Name)
+			for _, a := range method.Args {
+				g.Id(a.Name + ".arg").Call()
+			}
+		}))
+	})
+}
+
+// Generates the typed AssertCalled helper. For example:
+//
+// // AssertConcatCalled asserts that Concat was called with exactly these arguments.
+// func (_m *StringServiceMock) AssertConcatCalled(t mock.TestingT, a string, b string) bool {
+// 		return _m.AssertCalled(t, "Concat", a, b)
+// }
+func (b *testifyBackend) generateTypedAssertCalled(params *generatorParams, mockStructName string, method *types.Function, typeParams []TypeParam) *Statement {
+	assertName := "Assert" + method.Name + "Called"
+
+	return Commentf("%s asserts that %s was called with exactly these arguments.", assertName, method.Name).Line().
+		Func().Params(Id("_m").Id(fmt.Sprintf("*%s%s", mockStructName, typeParamsUseClause(typeParams)))).Id(assertName).ParamsFunc(func(g *Group) {
+		g.Id("t").Qual(testifyMockPackage, "TestingT")
+		for _, a := range method.Args {
+			g.Id(a.Name).Add(typeQual(params, typeParams, a.Type))
+		}
+	}).Bool().BlockFunc(func(g *Group) {
+		g.Return(Id("_m.AssertCalled").CallFunc(func(g *Group) {
+			g.Id("t")
+			g.Lit(method.Name)
+			for _, a := range method.Args {
+				g.Id(a.Name)
+			}
+		}))
+	})
+}
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+)
+
+// TestMatcherArgMatchesTestifyDynamicType renders the Matcher scaffolding
+// alongside a small program that feeds AnyOf's output into testify's own
+// mock.Arguments.Diff, for both a concrete type (string) and an
+// interface type (context.Context), and runs it. Diff compares against the
+// *dynamic* type of the real argument, so this is a real end-to-end check
+// of arg()'s AnythingOfType/Anything choice rather than an assumption from
+// the statically declared type parameter.
+func TestMatcherArgMatchesTestifyDynamicType(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gen-generator-mock-matcher-test")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	b := &testifyBackend{}
+	f := NewFilePath("main")
+	f.Add(b.generateMatcherType()).Line()
+	f.Func().Id("main").Params().Block(
+		Id("ctxMatcher").Op(":=").Id("AnyOf").Op("[").Qual("context", "Context").Op("]").Call(),
+		Id("stringMatcher").Op(":=").Id("AnyOf").Op("[").String().Op("]").Call(),
+		Line(),
+		Var().Id("realCtx").Qual("context", "Context").Op("=").Qual("context", "Background").Call(),
+		Var().Id("realString").String().Op("=").Lit("hello"),
+		Line(),
+		List(Id("_"), Id("diffCount")).Op(":=").Qual(testifyMockPackage, "Arguments").Values(
+			Id("ctxMatcher.arg").Call(),
+			Id("stringMatcher.arg").Call(),
+		).Dot("Diff").Call(Index().Interface().Values(Id("realCtx"), Id("realString"))),
+		If(Id("diffCount").Op("!=").Lit(0)).Block(
+			Qual("fmt", "Println").Call(Lit("FAIL")),
+			Qual("os", "Exit").Call(Lit(1)),
+		),
+		Qual("fmt", "Println").Call(Lit("OK")),
+	)
+
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%#v", f)), 0o644); err != nil {
+		t.Fatalf("write matcher test program: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "run", path)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("run matcher test program: %v: %s", err, stderr.String())
+	}
+	if got := stdout.String(); got != "OK\n" {
+		t.Fatalf("matcher test program output = %q, want %q", got, "OK\n")
+	}
+}
+
+// TestTypedExpectersSkipVariadicMethods checks hasVariadicArg, the predicate
+// Generate uses to leave typed On/AssertCalled helpers out for a method: it
+// must report true for a method with a variadic parameter and false for one
+// without, since Matcher[T] has no valid instantiation for a variadic
+// parameter's element type in bracket position (e.g. Matcher[...int] isn't
+// legal Go).
+func TestTypedExpectersSkipVariadicMethods(t *testing.T) {
+	method := &types.Function{
+		Base: types.Base{Name: "Printf"},
+		Args: []types.Variable{
+			{Base: types.Base{Name: "format"}, Type: types.TName{TypeName: "string"}},
+			{Base: types.Base{Name: "args"}, Type: types.TEllipsis{Next: types.TName{TypeName: "interface{}"}}},
+		},
+	}
+
+	if !hasVariadicArg(method) {
+		t.Fatalf("hasVariadicArg(%+v) = false, want true", method)
+	}
+
+	nonVariadic := &types.Function{
+		Base: types.Base{Name: "Concat"},
+		Args: []types.Variable{
+			{Base: types.Base{Name: "a"}, Type: types.TName{TypeName: "string"}},
+		},
+	}
+	if hasVariadicArg(nonVariadic) {
+		t.Fatalf("hasVariadicArg(%+v) = true, want false", nonVariadic)
+	}
+}